@@ -0,0 +1,123 @@
+package soliton
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+)
+
+// Kind identifies which distribution a Soliton was built from.
+type Kind uint8
+
+const (
+	// KindSoliton marks a Soliton built by NewSoliton.
+	KindSoliton Kind = iota
+	// KindRobustSoliton marks a Soliton built by NewRobustSoliton.
+	KindRobustSoliton
+)
+
+// String returns a human-readable name for k.
+func (k Kind) String() string {
+	switch k {
+	case KindSoliton:
+		return "Soliton"
+	case KindRobustSoliton:
+		return "RobustSoliton"
+	default:
+		return "Unknown"
+	}
+}
+
+// Params returns the parameters that define s: k, its Kind, and, for a
+// Robust Soliton, c and delta (both zero for a plain Soliton).
+func (s *Soliton) Params() (k uint64, kind Kind, c, delta float64) {
+	return s.k, s.kind, s.c, s.delta
+}
+
+// Splits returns a copy of the CDF partition underlying s.
+func (s *Soliton) Splits() []float64 {
+	out := make([]float64, len(s.splits))
+	copy(out, s.splits)
+	return out
+}
+
+// FromSplits reconstructs a Soliton directly from previously captured
+// parameters and partition (as returned by Params and Splits, or recovered
+// via UnmarshalBinary/UnmarshalJSON) rather than recomputing tau and ripple.
+// This lets an LT-code decoder reproduce the exact partition an encoder
+// used, which floating-point drift across Go versions or architectures could
+// otherwise desynchronize if both sides recomputed it independently. src is
+// the decoder's own source of randomness.
+func FromSplits(src Source, k uint64, kind Kind, c, delta float64, splits []float64) *Soliton {
+	out := make([]float64, len(splits))
+	copy(out, splits)
+	return &Soliton{src, k, kind, c, delta, out}
+}
+
+// SetSource attaches src as s's source of randomness. It is typically used
+// after reconstructing s via UnmarshalBinary or UnmarshalJSON, neither of
+// which persists the source.
+func (s *Soliton) SetSource(src Source) {
+	s.r = src
+}
+
+// MarshalBinary encodes s's parameters and partition, but not its source of
+// randomness, so that it can be rebuilt via UnmarshalBinary without
+// recomputing tau and ripple.
+func (s *Soliton) MarshalBinary() ([]byte, error) {
+	buf := new(bytes.Buffer)
+	for _, v := range []interface{}{s.k, uint8(s.kind), s.c, s.delta, uint64(len(s.splits)), s.splits} {
+		if err := binary.Write(buf, binary.LittleEndian, v); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+// UnmarshalBinary decodes data produced by MarshalBinary into s. The source
+// of randomness is left untouched; call SetSource before sampling from s.
+func (s *Soliton) UnmarshalBinary(data []byte) error {
+	buf := bytes.NewReader(data)
+	var kind uint8
+	var n uint64
+	for _, v := range []interface{}{&s.k, &kind, &s.c, &s.delta, &n} {
+		if err := binary.Read(buf, binary.LittleEndian, v); err != nil {
+			return err
+		}
+	}
+	s.kind = Kind(kind)
+	s.splits = make([]float64, n)
+	return binary.Read(buf, binary.LittleEndian, s.splits)
+}
+
+// solitonJSON is the wire format used by MarshalJSON/UnmarshalJSON. It omits
+// r, s's source of randomness, which is not serializable.
+type solitonJSON struct {
+	K      uint64    `json:"k"`
+	Kind   Kind      `json:"kind"`
+	C      float64   `json:"c,omitempty"`
+	Delta  float64   `json:"delta,omitempty"`
+	Splits []float64 `json:"splits"`
+}
+
+// MarshalJSON encodes s's parameters and partition, but not its source of
+// randomness, so that it can be rebuilt via UnmarshalJSON without
+// recomputing tau and ripple.
+func (s *Soliton) MarshalJSON() ([]byte, error) {
+	return json.Marshal(solitonJSON{s.k, s.kind, s.c, s.delta, s.splits})
+}
+
+// UnmarshalJSON decodes data produced by MarshalJSON into s. The source of
+// randomness is left untouched; call SetSource before sampling from s.
+func (s *Soliton) UnmarshalJSON(data []byte) error {
+	var v solitonJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	s.k = v.K
+	s.kind = v.Kind
+	s.c = v.C
+	s.delta = v.Delta
+	s.splits = v.Splits
+	return nil
+}
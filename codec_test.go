@@ -0,0 +1,83 @@
+package soliton
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestParamsSplits checks that Params and Splits report what the
+// constructors were called with.
+func TestParamsSplits(t *testing.T) {
+	s1 := NewSoliton(rng, 5)
+	k, kind, c, delta := s1.Params()
+	if k != 5 || kind != KindSoliton || c != 0 || delta != 0 {
+		t.Errorf("wrong params for NewSoliton: k=%v kind=%v c=%v delta=%v", k, kind, c, delta)
+	}
+	if len(s1.Splits()) != 5 {
+		t.Error("wrong splits length for NewSoliton")
+	}
+
+	s2 := NewRobustSoliton(rng, 5, 0.2, 0.05)
+	k, kind, c, delta = s2.Params()
+	if k != 5 || kind != KindRobustSoliton || c != 0.2 || delta != 0.05 {
+		t.Errorf("wrong params for NewRobustSoliton: k=%v kind=%v c=%v delta=%v", k, kind, c, delta)
+	}
+}
+
+// TestFromSplits checks that FromSplits reconstructs an identical
+// distribution without recomputing tau and ripple.
+func TestFromSplits(t *testing.T) {
+	orig := NewRobustSoliton(rng, 10, 0.2, 0.05)
+	k, kind, c, delta := orig.Params()
+	rebuilt := FromSplits(rng, k, kind, c, delta, orig.Splits())
+	if !orig.Equals(rebuilt) {
+		t.Error("FromSplits did not reconstruct an equal distribution")
+	}
+}
+
+// TestBinaryRoundTrip checks that a Soliton survives a MarshalBinary /
+// UnmarshalBinary round trip with Equals still holding.
+func TestBinaryRoundTrip(t *testing.T) {
+	for _, orig := range []*Soliton{
+		NewSoliton(rng, 20),
+		NewRobustSoliton(rng, 20, 0.2, 0.05),
+	} {
+		data, err := orig.MarshalBinary()
+		if err != nil {
+			t.Fatalf("MarshalBinary: %v", err)
+		}
+		got := &Soliton{}
+		if err := got.UnmarshalBinary(data); err != nil {
+			t.Fatalf("UnmarshalBinary: %v", err)
+		}
+		if !orig.Equals(got) {
+			t.Error("Soliton did not survive binary round trip")
+		}
+		ok, okind, oc, odelta := orig.Params()
+		gk, gkind, gc, gdelta := got.Params()
+		if ok != gk || okind != gkind || oc != gc || odelta != gdelta {
+			t.Error("Params did not survive binary round trip")
+		}
+	}
+}
+
+// TestJSONRoundTrip checks that a Soliton survives a MarshalJSON /
+// UnmarshalJSON round trip with Equals still holding.
+func TestJSONRoundTrip(t *testing.T) {
+	for _, orig := range []*Soliton{
+		NewSoliton(rng, 20),
+		NewRobustSoliton(rng, 20, 0.2, 0.05),
+	} {
+		data, err := json.Marshal(orig)
+		if err != nil {
+			t.Fatalf("json.Marshal: %v", err)
+		}
+		got := &Soliton{}
+		if err := json.Unmarshal(data, got); err != nil {
+			t.Fatalf("json.Unmarshal: %v", err)
+		}
+		if !orig.Equals(got) {
+			t.Error("Soliton did not survive JSON round trip")
+		}
+	}
+}
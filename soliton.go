@@ -4,22 +4,25 @@ package soliton
 
 import (
 	"math"
-	"math/rand"
 	"sort"
 )
 
 // Soliton generates Soliton distributed variates.
 type Soliton struct {
-	r      *rand.Rand
-	k      uint64
-	splits []float64 // the entire range of [0, 1) is cut into k pieces with k-1 splits
+	r        Source
+	k        uint64
+	kind     Kind
+	c, delta float64   // zero for a plain Soliton; see NewRobustSoliton
+	splits   []float64 // the entire range of [0, 1) is cut into k pieces with k-1 splits
 }
 
 // NewRobustSoliton returns a Robust Soliton variate generator that uses
-// src as the source of randomness. See
+// src as the source of randomness. src may be a *math/rand.Rand, a
+// *math/rand/v2.Rand, or any other Source; see FromMathRandV2 and
+// FromCryptoReader for adapting other generators. See
 // https://en.wikipedia.org/wiki/Soliton_distribution#Robust_distribution
 // for definitions of the parameters k, c, and delta.
-func NewRobustSoliton(src *rand.Rand, k uint64, c, delta float64) *Soliton {
+func NewRobustSoliton(src Source, k uint64, c, delta float64) *Soliton {
 	var sum []float64
 	tot := 0.0
 	var i uint64
@@ -31,15 +34,15 @@ func NewRobustSoliton(src *rand.Rand, k uint64, c, delta float64) *Soliton {
 		sum[i] /= tot
 	}
 	sum = append(sum, 1.0)
-	return &Soliton{src, k, sum[1:]}
+	return &Soliton{src, k, KindRobustSoliton, c, delta, sum[1:]}
 }
 
 // tau implements the function tau for the robust Soliton distribution
 func tau(c, delta float64, k, i uint64) float64 {
 	r := ripple(c, delta, k)
 	th := uint64(math.Round(float64(k) / r))
-	if i < th {                              // 1 to k/R-1
-		return r / float64(i * k)
+	if i < th { // 1 to k/R-1
+		return r / float64(i*k)
 	} else if i == th { // k/R
 		return r * (math.Log(r) - math.Log(delta)) / float64(k)
 	} else { // k/R+1 to k
@@ -59,21 +62,24 @@ func rho(k, i uint64) float64 {
 	if i == 1 {
 		return 1.0 / float64(k)
 	} else {
-		return 1.0 / float64(i * (i-1))
+		return 1.0 / float64(i*(i-1))
 	}
 }
 
 /*
 NewSoliton returns a Soliton variate generator that uses
-src as the source of randomness. The distribution has a single
+src as the source of randomness. src may be a *math/rand.Rand, a
+*math/rand/v2.Rand, or any other Source; see FromMathRandV2 and
+FromCryptoReader for adapting other generators. The distribution has a single
 parameter, k. The PDF is given by
+
 	p(1) = 1/k
 	P(i) = 1/(i*(i-1)).
 
 See https://en.wikipedia.org/wiki/Soliton_distribution for a
 more detailed description of Soliton and related distributions.
 */
-func NewSoliton(src *rand.Rand, k uint64) *Soliton {
+func NewSoliton(src Source, k uint64) *Soliton {
 	var s []float64
 	last := 0.0
 	var i uint64
@@ -83,7 +89,7 @@ func NewSoliton(src *rand.Rand, k uint64) *Soliton {
 		s = append(s, last)
 	}
 	s = append(s, 1.0)
-	return &Soliton{src, k, s}
+	return &Soliton{src, k, KindSoliton, 0, 0, s}
 }
 
 // Uint64 returns a value drawn from the Soliton or Robust Soliton distribution
@@ -97,6 +103,63 @@ func (s *Soliton) Uint64() uint64 {
 	return uint64(idx + 1)
 }
 
+// Fill draws len(p) values from the Soliton or Robust Soliton distribution
+// described by s and stores them in p. It is intended for callers that need
+// large batches, such as an LT encoder producing millions of degrees: rather
+// than doing a binary search over splits for every draw, Fill generates the
+// n uniforms already in sorted order (via the spacings between n+1 points
+// dropped on [0, 1), a standard order-statistics trick that costs O(n) and
+// avoids an O(n log n) sort), walks them against splits once, merge-style,
+// and then shuffles the result back into an unbiased order. That's O(n + k)
+// total instead of O(n log k), which wins once n is large relative to k.
+func (s *Soliton) Fill(p []uint64) {
+	n := len(p)
+	if n == 0 {
+		return
+	}
+	u := make([]float64, n)
+	sum := 0.0
+	for i := range u {
+		sum += -math.Log(1 - s.r.Float64()) // 1-Float64() avoids log(0)
+		u[i] = sum
+	}
+	sum += -math.Log(1 - s.r.Float64())
+	for i := range u {
+		u[i] /= sum // u is now n sorted uniform variates on [0, 1)
+	}
+
+	bin := 0
+	for i, x := range u {
+		for bin < len(s.splits) && s.splits[bin] < x {
+			bin++
+		}
+		if uint64(bin) >= s.k {
+			panic("r should never be larger than the last item in s")
+		}
+		p[i] = uint64(bin) + 1
+	}
+
+	// p was filled in ascending order; the samples it holds are still iid,
+	// so a uniformly random permutation recovers an unbiased sequence.
+	for i := n - 1; i > 0; i-- {
+		j := int(s.r.Float64() * float64(i+1))
+		if j > i { // guard against the extremely rare Float64() == 1.0
+			j = i
+		}
+		p[i], p[j] = p[j], p[i]
+	}
+}
+
+// Read draws len(p) values from the Soliton or Robust Soliton distribution
+// described by s and stores them in p, as Fill does. It implements the same
+// shape as io.Reader so a Soliton can be plugged into code that streams
+// batches of values, and it always fills p completely and returns a nil
+// error.
+func (s *Soliton) Read(p []uint64) (int, error) {
+	s.Fill(p)
+	return len(p), nil
+}
+
 // Equals compares the two soliton distributions by comparing the partition.
 func (s *Soliton) Equals(s2 *Soliton) bool {
 	if s.k != s2.k {
@@ -118,10 +181,10 @@ func (s *Soliton) Mean() float64 {
 	res := 0.0
 	lastCdf := 0.0
 	for i := range s.splits {
-		res += (s.splits[i]-lastCdf) * float64(i+1)
+		res += (s.splits[i] - lastCdf) * float64(i+1)
 		lastCdf = s.splits[i]
 	}
-	res += (1.0-lastCdf) * float64(s.k)
+	res += (1.0 - lastCdf) * float64(s.k)
 	return res
 }
 
@@ -136,3 +199,216 @@ func (s *Soliton) PMF() []float64 {
 	}
 	return res
 }
+
+// SolitonAlias generates variates from a Soliton or Robust Soliton
+// distribution using Vose's alias method. Unlike Soliton, which does a
+// binary search over the CDF for every draw, SolitonAlias precomputes an
+// alias table so that Uint64 runs in O(1) regardless of k.
+type SolitonAlias struct {
+	r     Source
+	k     uint64
+	prob  []float64
+	alias []uint64
+}
+
+// NewSolitonAlias returns a Soliton variate generator that uses src as the
+// source of randomness and samples in O(1) via Vose's alias method. See
+// NewSoliton for the definition of the distribution and of src.
+func NewSolitonAlias(src Source, k uint64) *SolitonAlias {
+	return newSolitonAlias(src, NewSoliton(src, k))
+}
+
+// NewRobustSolitonAlias returns a Robust Soliton variate generator that uses
+// src as the source of randomness and samples in O(1) via Vose's alias
+// method. See NewRobustSoliton for the definition of the parameters k, c,
+// delta, and src.
+func NewRobustSolitonAlias(src Source, k uint64, c, delta float64) *SolitonAlias {
+	return newSolitonAlias(src, NewRobustSoliton(src, k, c, delta))
+}
+
+// newSolitonAlias builds the alias table for the distribution described by
+// s's PMF.
+func newSolitonAlias(src Source, s *Soliton) *SolitonAlias {
+	prob, alias := buildAliasTable(s.PMF())
+	return &SolitonAlias{src, s.k, prob, alias}
+}
+
+// buildAliasTable constructs the probability and alias tables for Vose's
+// alias method from pmf, the probability mass function of the distribution.
+func buildAliasTable(pmf []float64) ([]float64, []uint64) {
+	k := len(pmf)
+	prob := make([]float64, k)
+	alias := make([]uint64, k)
+	scaled := make([]float64, k)
+	small := make([]int, 0, k)
+	large := make([]int, 0, k)
+	for i, p := range pmf {
+		scaled[i] = p * float64(k)
+		if scaled[i] < 1.0 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s := small[len(small)-1]
+		small = small[:len(small)-1]
+		l := large[len(large)-1]
+		large = large[:len(large)-1]
+		prob[s] = scaled[s]
+		alias[s] = uint64(l)
+		scaled[l] = scaled[l] + scaled[s] - 1.0
+		if scaled[l] < 1.0 {
+			small = append(small, l)
+		} else {
+			large = append(large, l)
+		}
+	}
+	// leftover indices accumulated rounding error rather than genuinely
+	// needing an alias; treat them as certain.
+	for _, l := range large {
+		prob[l] = 1.0
+	}
+	for _, s := range small {
+		prob[s] = 1.0
+	}
+	return prob, alias
+}
+
+// Uint64 returns a value drawn from the Soliton or Robust Soliton
+// distribution described by the SolitonAlias object, in O(1) time.
+func (s *SolitonAlias) Uint64() uint64 {
+	i := uint64(s.r.Float64() * float64(s.k))
+	if i >= s.k { // guard against the extremely rare Float64() == 1.0
+		i = s.k - 1
+	}
+	u := s.r.Float64()
+	if u < s.prob[i] {
+		return i + 1
+	}
+	return s.alias[i] + 1
+}
+
+// ChiSquare performs a chi-square goodness-of-fit test of samples (as drawn
+// from, e.g., Uint64 or SolitonAlias.Uint64) against the distribution
+// described by s's PMF. Samples are bucketed by value, and adjacent bins are
+// merged, walking up from value 1, until each merged bin's expected count is
+// at least 5, the usual rule of thumb for the chi-square approximation to be
+// valid. It returns the statistic
+//
+//	chi^2 = sum((observed_i - expected_i)^2 / expected_i)
+//
+// and the p-value under a chi-square distribution with (bins-1) degrees of
+// freedom.
+func (s *Soliton) ChiSquare(samples []uint64) (stat, pValue float64) {
+	pmf := s.PMF()
+	observed := make([]float64, s.k)
+	for _, v := range samples {
+		observed[v-1]++
+	}
+	n := float64(len(samples))
+	expected := make([]float64, s.k)
+	for i, p := range pmf {
+		expected[i] = p * n
+	}
+
+	var obsBins, expBins []float64
+	oAcc, eAcc := 0.0, 0.0
+	for i := 0; i < int(s.k); i++ {
+		oAcc += observed[i]
+		eAcc += expected[i]
+		if eAcc >= 5 || i == int(s.k)-1 {
+			obsBins = append(obsBins, oAcc)
+			expBins = append(expBins, eAcc)
+			oAcc, eAcc = 0, 0
+		}
+	}
+	// the last bin may still be under 5 if the tail of the PMF is thin;
+	// fold it into its neighbor so every bin used below is valid.
+	for len(expBins) > 1 && expBins[len(expBins)-1] < 5 {
+		last := len(expBins) - 1
+		obsBins[last-1] += obsBins[last]
+		expBins[last-1] += expBins[last]
+		obsBins = obsBins[:last]
+		expBins = expBins[:last]
+	}
+
+	for i := range expBins {
+		d := obsBins[i] - expBins[i]
+		stat += d * d / expBins[i]
+	}
+	dof := float64(len(expBins) - 1)
+	pValue = regularizedGammaQ(dof/2, stat/2)
+	return stat, pValue
+}
+
+// the incomplete-gamma machinery below backs ChiSquare's p-value computation
+// and follows the standard series/continued-fraction split (Numerical
+// Recipes' gser/gcf) for evaluating the regularized incomplete gamma
+// function across its full domain.
+const (
+	igamMaxIter = 200
+	igamEpsilon = 3e-12
+)
+
+// regularizedGammaQ returns Q(a, x), the upper regularized incomplete gamma
+// function, which is the survival function of a chi-square distribution
+// with 2a degrees of freedom evaluated at 2x.
+func regularizedGammaQ(a, x float64) float64 {
+	if x < a+1 {
+		return 1 - gammaSeries(a, x)
+	}
+	return gammaContinuedFraction(a, x)
+}
+
+// gammaSeries evaluates the lower regularized incomplete gamma function
+// P(a, x) via its power series. Converges quickly for x < a+1.
+func gammaSeries(a, x float64) float64 {
+	if x == 0 {
+		return 0
+	}
+	gln, _ := math.Lgamma(a)
+	ap := a
+	sum := 1.0 / a
+	del := sum
+	for n := 0; n < igamMaxIter; n++ {
+		ap++
+		del *= x / ap
+		sum += del
+		if math.Abs(del) < math.Abs(sum)*igamEpsilon {
+			break
+		}
+	}
+	return sum * math.Exp(-x+a*math.Log(x)-gln)
+}
+
+// gammaContinuedFraction evaluates the upper regularized incomplete gamma
+// function Q(a, x) via Lentz's continued-fraction method. Converges quickly
+// for x >= a+1.
+func gammaContinuedFraction(a, x float64) float64 {
+	const tiny = 1e-300
+	gln, _ := math.Lgamma(a)
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for i := 1; i <= igamMaxIter; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < igamEpsilon {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-gln) * h
+}
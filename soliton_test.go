@@ -1,10 +1,11 @@
 package soliton
 
 import (
+	"fmt"
 	"math"
 	"math/rand"
-	"testing"
 	"strconv"
+	"testing"
 )
 
 // constRNG implements rand.Source and always returns one value.
@@ -35,6 +36,45 @@ func BenchmarkSample(b *testing.B) {
 	}
 }
 
+func BenchmarkSampleAlias(b *testing.B) {
+	ks := []int{10, 50, 100, 200, 1000, 10000, 100000}
+	for _, k := range ks {
+		name := strconv.Itoa(k)
+		dist := NewSolitonAlias(rng, uint64(k))
+		b.Run(name, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				dist.Uint64()
+			}
+		})
+	}
+}
+
+func BenchmarkFill(b *testing.B) {
+	ks := []int{100, 10000}
+	ns := []int{100, 10000, 1000000}
+	for _, k := range ks {
+		dist := NewSoliton(rng, uint64(k))
+		for _, n := range ns {
+			p := make([]uint64, n)
+			b.Run(fmt.Sprintf("k=%d/loop/n=%d", k, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					for j := range p {
+						p[j] = dist.Uint64()
+					}
+				}
+			})
+			b.Run(fmt.Sprintf("k=%d/fill/n=%d", k, n), func(b *testing.B) {
+				b.ReportAllocs()
+				for i := 0; i < b.N; i++ {
+					dist.Fill(p)
+				}
+			})
+		}
+	}
+}
+
 // TestUint64 tests the sampling of soliton distribution.
 func TestUint64(t *testing.T) {
 	r1 := rand.New(constRNG{0})
@@ -44,7 +84,7 @@ func TestUint64(t *testing.T) {
 	}
 
 	var norm float64
-	norm = 1<<63
+	norm = 1 << 63
 	r2 := rand.New(constRNG{int64(0.35 * norm)})
 	s2 := NewSoliton(r2, 3)
 	if s2.Uint64() != 2 {
@@ -136,6 +176,122 @@ func TestSolitonUint64(t *testing.T) {
 	s.Uint64()
 }
 
+// TestFill checks that Fill stores one in-range value per slot, matching
+// the CDF implied by PMF (checked here loosely via a chi-square test).
+func TestFill(t *testing.T) {
+	dist := NewSoliton(rng, 50)
+	samples := make([]uint64, 200000)
+	dist.Fill(samples)
+	for _, v := range samples {
+		if v < 1 || v > 50 {
+			t.Errorf("sampled value %d out of range [1, 50]", v)
+		}
+	}
+	if _, p := dist.ChiSquare(samples); p < 0.01 {
+		t.Errorf("Fill samples rejected by chi-square test, p=%v", p)
+	}
+}
+
+// TestFillEmpty checks that Fill is a no-op on an empty slice.
+func TestFillEmpty(t *testing.T) {
+	dist := NewSoliton(rng, 5)
+	dist.Fill(nil)
+}
+
+// TestRead checks that Read fills p completely and returns no error.
+func TestRead(t *testing.T) {
+	dist := NewSoliton(rng, 50)
+	p := make([]uint64, 1000)
+	n, err := dist.Read(p)
+	if err != nil {
+		t.Errorf("Read returned an error: %v", err)
+	}
+	if n != len(p) {
+		t.Errorf("Read reported %d values filled, want %d", n, len(p))
+	}
+	for _, v := range p {
+		if v < 1 || v > 50 {
+			t.Errorf("sampled value %d out of range [1, 50]", v)
+		}
+	}
+}
+
+// TestSolitonAliasUint64 tests drawing uint64 values from a Soliton
+// distribution sampled via the alias method.
+func TestSolitonAliasUint64(t *testing.T) {
+	s := NewSolitonAlias(rng, 1)
+	r := s.Uint64()
+	if r != 1 {
+		t.Error("drawing from k=1 soliton distribution is not 1")
+	}
+}
+
+// TestSolitonAliasRange tests that samples drawn from the alias-based
+// Soliton and Robust Soliton distributions always fall within [1, k].
+func TestSolitonAliasRange(t *testing.T) {
+	s1 := NewSolitonAlias(rng, 100)
+	for i := 0; i < 10000; i++ {
+		v := s1.Uint64()
+		if v < 1 || v > 100 {
+			t.Errorf("sampled value %d out of range [1, 100]", v)
+		}
+	}
+
+	s2 := NewRobustSolitonAlias(rng, 100, 0.2, 0.05)
+	for i := 0; i < 10000; i++ {
+		v := s2.Uint64()
+		if v < 1 || v > 100 {
+			t.Errorf("sampled value %d out of range [1, 100]", v)
+		}
+	}
+}
+
+// TestChiSquareSoliton checks that samples drawn from plain Soliton
+// distributions are not rejected by their own chi-square goodness-of-fit
+// test at alpha=0.01, across several values of k.
+func TestChiSquareSoliton(t *testing.T) {
+	const alpha = 0.01
+	const n = 200000
+	for _, k := range []uint64{5, 20, 100, 500} {
+		dist := NewSoliton(rng, k)
+		samples := make([]uint64, n)
+		for i := range samples {
+			samples[i] = dist.Uint64()
+		}
+		stat, p := dist.ChiSquare(samples)
+		if p < alpha {
+			t.Errorf("k=%d: chi-square test rejected null hypothesis (stat=%v, p=%v)", k, stat, p)
+		}
+	}
+}
+
+// TestChiSquareRobustSoliton checks that samples drawn from Robust Soliton
+// distributions are not rejected by their own chi-square goodness-of-fit
+// test at alpha=0.01, across several values of k, c, and delta.
+func TestChiSquareRobustSoliton(t *testing.T) {
+	const alpha = 0.01
+	const n = 200000
+	cases := []struct {
+		k        uint64
+		c, delta float64
+	}{
+		{20, 0.2, 0.05},
+		{100, 0.1, 0.01},
+		{500, 0.3, 0.2},
+	}
+	for _, tc := range cases {
+		dist := NewRobustSoliton(rng, tc.k, tc.c, tc.delta)
+		samples := make([]uint64, n)
+		for i := range samples {
+			samples[i] = dist.Uint64()
+		}
+		stat, p := dist.ChiSquare(samples)
+		if p < alpha {
+			t.Errorf("k=%d c=%v delta=%v: chi-square test rejected null hypothesis (stat=%v, p=%v)", tc.k, tc.c, tc.delta, stat, p)
+		}
+	}
+}
+
 // TestSolitonEqual tests the comparator of two Soliton distributions.
 func TestSolitonEqual(t *testing.T) {
 	s1 := NewSoliton(rng, 4)
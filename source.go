@@ -0,0 +1,53 @@
+package soliton
+
+import (
+	"encoding/binary"
+	"io"
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// Source is the minimal source of randomness a Soliton or SolitonAlias
+// needs: a uniform variate in [0, 1). Both *math/rand.Rand and
+// *math/rand/v2.Rand already satisfy it, so existing callers built around
+// math/rand keep working with no changes. Use FromMathRandV2 or
+// FromCryptoReader to plug in math/rand/v2 generators (PCG, ChaCha8) or a
+// crypto/rand reader.
+type Source interface {
+	Float64() float64
+}
+
+// FromMathRand adapts a *math/rand.Rand to Source. It is provided for
+// symmetry with FromMathRandV2 and FromCryptoReader; since *rand.Rand
+// already implements Source, passing r directly works just as well.
+func FromMathRand(r *rand.Rand) Source {
+	return r
+}
+
+// FromMathRandV2 adapts a *math/rand/v2.Rand (e.g. built on rand.NewPCG or
+// rand.NewChaCha8) to Source.
+func FromMathRandV2(r *randv2.Rand) Source {
+	return r
+}
+
+// cryptoSource adapts an io.Reader, typically crypto/rand.Reader, to Source.
+type cryptoSource struct {
+	r io.Reader
+}
+
+// Float64 returns a uniform variate in [0, 1), built from 53 random bits
+// read from the underlying reader, matching the precision math/rand uses
+// for its own Float64.
+func (c cryptoSource) Float64() float64 {
+	var buf [8]byte
+	if _, err := io.ReadFull(c.r, buf[:]); err != nil {
+		panic("soliton: failed to read from crypto source: " + err.Error())
+	}
+	v := binary.BigEndian.Uint64(buf[:])
+	return float64(v>>11) / (1 << 53)
+}
+
+// FromCryptoReader adapts r, typically crypto/rand.Reader, to Source.
+func FromCryptoReader(r io.Reader) Source {
+	return cryptoSource{r}
+}
@@ -0,0 +1,44 @@
+package soliton
+
+import (
+	cryptorand "crypto/rand"
+	randv2 "math/rand/v2"
+	"testing"
+)
+
+// TestFromMathRand checks that a *math/rand.Rand adapted via FromMathRand
+// still drives NewSoliton correctly.
+func TestFromMathRand(t *testing.T) {
+	s := NewSoliton(FromMathRand(rng), 100)
+	for i := 0; i < 1000; i++ {
+		v := s.Uint64()
+		if v < 1 || v > 100 {
+			t.Errorf("sampled value %d out of range [1, 100]", v)
+		}
+	}
+}
+
+// TestFromMathRandV2 checks that a *math/rand/v2.Rand adapted via
+// FromMathRandV2 drives NewSoliton correctly.
+func TestFromMathRandV2(t *testing.T) {
+	r := randv2.New(randv2.NewPCG(0, 0))
+	s := NewSoliton(FromMathRandV2(r), 100)
+	for i := 0; i < 1000; i++ {
+		v := s.Uint64()
+		if v < 1 || v > 100 {
+			t.Errorf("sampled value %d out of range [1, 100]", v)
+		}
+	}
+}
+
+// TestFromCryptoReader checks that crypto/rand.Reader adapted via
+// FromCryptoReader drives NewSoliton correctly.
+func TestFromCryptoReader(t *testing.T) {
+	s := NewSoliton(FromCryptoReader(cryptorand.Reader), 100)
+	for i := 0; i < 1000; i++ {
+		v := s.Uint64()
+		if v < 1 || v > 100 {
+			t.Errorf("sampled value %d out of range [1, 100]", v)
+		}
+	}
+}